@@ -1,73 +1,148 @@
 package cache
 
-// ARCCache is Adaptive Replacement Cache (ARC).
-type ARCCache struct {
-	size int // Size is the total capacity of the cache
-	p    int // P is the dynamic preference towards T1 or T2
+import (
+	"sync"
+	"time"
+)
 
-	t1 *LRU // T1 is the LRU for recently accessed items
-	b1 *LRU // B1 is the LRU for evictions from t1
+// ARCCache is Adaptive Replacement Cache (ARC), generic over key type K
+// and value type V. It is safe for concurrent use by multiple goroutines.
+type ARCCache[K comparable, V any] struct {
+	mu sync.RWMutex
 
-	t2 *LRU // T2 is the LRU for frequently accessed items
-	b2 *LRU // B2 is the LRU for evictions from t2
+	size       int // Size is the total capacity of the cache
+	p          int // P is the dynamic preference towards T1 or T2
+	defaultTTL time.Duration
+
+	t1 *LRU[K, V]   // T1 is the LRU for recently accessed items
+	b1 *LRU[K, any] // B1 is the LRU for evictions from t1
+
+	t2 *LRU[K, V]   // T2 is the LRU for frequently accessed items
+	b2 *LRU[K, any] // B2 is the LRU for evictions from t2
+
+	onEvict func(key K, value V, reason EvictReason)
+	stats   Stats
+
+	janitorStop chan struct{}
+}
+
+// Uint64ARCCache is the pre-generics instantiation of ARCCache, kept so
+// existing callers using uint64 keys and any values keep compiling
+// unchanged.
+type Uint64ARCCache = ARCCache[uint64, any]
+
+// NewGenericARC constructs an ARCCache generic over key type K and value
+// type V. Use NewARC for the pre-generics uint64/any instantiation.
+func NewGenericARC[K comparable, V any](size int, opts ...Option[K, V]) (*ARCCache[K, V], error) {
+	return newARC[K, V](size, 0, opts)
+}
+
+// NewARC constructs an ARCCache[uint64, any], matching the signature this
+// package exposed before the generics migration. Use NewGenericARC for
+// other key/value types.
+func NewARC(size int, opts ...Option[uint64, any]) (*Uint64ARCCache, error) {
+	return NewGenericARC[uint64, any](size, opts...)
+}
+
+// NewARCWithTTL constructs an ARCCache whose entries expire defaultTTL
+// after being written, unless overridden per-entry via PutWithTTL. See
+// NewLRUWithTTL for expiration semantics.
+func NewARCWithTTL[K comparable, V any](size int, defaultTTL time.Duration, opts ...Option[K, V]) (*ARCCache[K, V], error) {
+	return newARC[K, V](size, defaultTTL, opts)
 }
 
-func NewARC(size int) (*ARCCache, error) {
-	t1, err := NewLRU(size)
+func newARC[K comparable, V any](size int, defaultTTL time.Duration, opts []Option[K, V]) (*ARCCache[K, V], error) {
+	t1, err := NewLRUWithTTL[K, V](size, defaultTTL)
 	if err != nil {
 		return nil, err
 	}
-	b1, err := NewLRU(size)
+	b1, err := NewGenericLRU[K, any](size)
 	if err != nil {
 		return nil, err
 	}
-	t2, err := NewLRU(size)
+	t2, err := NewLRUWithTTL[K, V](size, defaultTTL)
 	if err != nil {
 		return nil, err
 	}
-	b2, err := NewLRU(size)
+	b2, err := NewGenericLRU[K, any](size)
 	if err != nil {
 		return nil, err
 	}
-	return &ARCCache{
-		size: size,
-		p:    0,
-		t1:   t1,
-		b1:   b1,
-		t2:   t2,
-		b2:   b2,
-	}, nil
+	o := buildOptions(opts)
+	c := &ARCCache[K, V]{
+		size:       size,
+		p:          0,
+		defaultTTL: defaultTTL,
+		t1:         t1,
+		b1:         b1,
+		t2:         t2,
+		b2:         b2,
+		onEvict:    o.OnEvict,
+	}
+
+	// Entries that expire inside T1/T2 still leave the cache for good,
+	// whether noticed lazily on access or by sweepAll; report them the
+	// same way as any other eviction.
+	t1.onExpire(func(key K, value V) { c.notifyEvict(key, value, ReasonEvicted) })
+	t2.onExpire(func(key K, value V) { c.notifyEvict(key, value, ReasonEvicted) })
+
+	return c, nil
+}
+
+// NewUint64ARC is an explicit-name alias for NewARC.
+func NewUint64ARC(size int, opts ...Option[uint64, any]) (*Uint64ARCCache, error) {
+	return NewARC(size, opts...)
 }
 
-func (c *ARCCache) Get(key uint64) (any, bool) {
+func (c *ARCCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// If the value is contained in T1 (recent), then
 	// promote it to T2 (frequent)
-	if value, ok := c.t1.Peek(key); ok {
+	if item, ok := c.t1.peekItem(key); ok {
 		c.t1.Remove(key)
-		c.t2.Put(key, value)
-		return value, ok
+		c.t2.putItem(key, item.Value, item.expiresAt)
+		c.stats.Hits++
+		return item.Value, true
 	}
 
 	// Check if the value is contained in T2 (frequent)
 	if value, ok := c.t2.Get(key); ok {
+		c.stats.Hits++
 		return value, ok
 	}
 
-	return nil, false
+	c.stats.Misses++
+	var zero V
+	return zero, false
+}
+
+// Put adds a value to the cache using the cache's default TTL, if one was
+// configured via NewARCWithTTL.
+func (c *ARCCache[K, V]) Put(key K, value V) {
+	c.PutWithTTL(key, value, c.defaultTTL)
 }
 
-func (c *ARCCache) Put(key uint64, value any) {
+// PutWithTTL adds a value to the cache that expires after ttl. A ttl of
+// zero or less means the entry never expires.
+func (c *ARCCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// Check if the value is contained in T1 (recent), and potentially
 	// promote it to frequent T2
-	if c.t1.Contains(key) {
+	if old, ok := c.t1.Peek(key); ok {
 		c.t1.Remove(key)
-		c.t2.Put(key, value)
+		c.t2.PutWithTTL(key, value, ttl)
+		c.notifyEvict(key, old, ReasonReplaced)
 		return
 	}
 
 	// Check if the value is already in T2 (frequent) and update it
-	if c.t2.Contains(key) {
-		c.t2.Put(key, value)
+	if old, ok := c.t2.Peek(key); ok {
+		c.t2.PutWithTTL(key, value, ttl)
+		c.notifyEvict(key, old, ReasonReplaced)
 		return
 	}
 
@@ -96,7 +171,7 @@ func (c *ARCCache) Put(key uint64, value any) {
 		c.b1.Remove(key)
 
 		// Add the key to the frequently used list
-		c.t2.Put(key, value)
+		c.t2.PutWithTTL(key, value, ttl)
 		return
 	}
 
@@ -125,7 +200,7 @@ func (c *ARCCache) Put(key uint64, value any) {
 		c.b2.Remove(key)
 
 		// Add the key to the frequently used list
-		c.t2.Put(key, value)
+		c.t2.PutWithTTL(key, value, ttl)
 		return
 	}
 
@@ -143,61 +218,179 @@ func (c *ARCCache) Put(key uint64, value any) {
 	}
 
 	// Add to the recently seen list
-	c.t1.Put(key, value)
+	c.t1.PutWithTTL(key, value, ttl)
 }
 
 // replace is used to adaptively evict from either T1 or T2
-// based on the current learned value of P
-func (c *ARCCache) replace(b2ContainsKey bool) {
+// based on the current learned value of P. Callers must hold c.mu.
+func (c *ARCCache[K, V]) replace(b2ContainsKey bool) {
 	if c.t1.Len() > 0 && (c.t1.Len() > c.p || (c.t1.Len() == c.p && b2ContainsKey)) {
-		k, _, ok := c.t1.GetAndRemoveOldest()
-		if ok {
+		if k, v, ok := c.t1.GetAndRemoveOldest(); ok {
 			c.b1.Put(k, nil)
+			c.notifyEvict(k, v, ReasonEvictedToGhost)
 		}
 	} else {
-		k, _, ok := c.t2.GetAndRemoveOldest()
-		if ok {
+		if k, v, ok := c.t2.GetAndRemoveOldest(); ok {
 			c.b2.Put(k, nil)
+			c.notifyEvict(k, v, ReasonEvictedToGhost)
 		}
 	}
 }
 
-func (c *ARCCache) Peek(key uint64) (any, bool) {
+// notifyEvict updates eviction stats and invokes the OnEvict callback, if
+// any. Callers must hold c.mu.
+func (c *ARCCache[K, V]) notifyEvict(key K, value V, reason EvictReason) {
+	if reason == ReasonEvicted || reason == ReasonEvictedToGhost {
+		c.stats.Evictions++
+	}
+	if c.onEvict != nil {
+		c.onEvict(key, value, reason)
+	}
+}
+
+// Peek takes the full lock, not RLock, because a peeked entry found
+// expired in t1/t2 is removed on the spot and reported through the
+// expiry hook, which mutates c.stats and may invoke c.onEvict.
+func (c *ARCCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if value, ok := c.t1.Peek(key); ok {
+		c.stats.Hits++
 		return value, ok
 	}
-	return c.t2.Peek(key)
+	if value, ok := c.t2.Peek(key); ok {
+		c.stats.Hits++
+		return value, ok
+	}
+	c.stats.Misses++
+	var zero V
+	return zero, false
 }
 
-func (c *ARCCache) Remove(key uint64) {
-	if c.t1.Remove(key) {
-		return
+func (c *ARCCache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if val, ok := c.t1.Peek(key); ok {
+		c.t1.Remove(key)
+		c.notifyEvict(key, val, ReasonRemoved)
+		return true
 	}
-	if c.t2.Remove(key) {
-		return
+	if val, ok := c.t2.Peek(key); ok {
+		c.t2.Remove(key)
+		c.notifyEvict(key, val, ReasonRemoved)
+		return true
 	}
 	if c.b1.Remove(key) {
-		return
-	}
-	if c.b2.Remove(key) {
-		return
+		return true
 	}
+	return c.b2.Remove(key)
 }
 
-func (c *ARCCache) Purge() {
+func (c *ARCCache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.onEvict != nil {
+		for _, it := range c.t1.Items() {
+			c.onEvict(it.Key, it.Value, ReasonPurged)
+		}
+		for _, it := range c.t2.Items() {
+			c.onEvict(it.Key, it.Value, ReasonPurged)
+		}
+	}
+
 	c.t1.Purge()
 	c.t2.Purge()
 	c.b1.Purge()
 	c.b2.Purge()
 }
 
-func (c *ARCCache) Items() []*Item {
-	elems := make([]*Item, 0, c.Len())
+// Items takes the full lock, not RLock, for the same reason as Peek:
+// pruning expired entries out of t1/t2 mutates c.stats via the expiry
+// hook.
+func (c *ARCCache[K, V]) Items() []*Item[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elems := make([]*Item[K, V], 0, c.t1.Len()+c.t2.Len())
 	elems = append(elems, c.t1.Items()...)
 	elems = append(elems, c.t2.Items()...)
 	return elems
 }
 
-func (c *ARCCache) Len() int {
+// Len takes the full lock, not RLock, for the same reason as Peek:
+// pruning expired entries out of t1/t2 mutates c.stats via the expiry
+// hook.
+func (c *ARCCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	return c.t1.Len() + c.t2.Len()
 }
+
+// Stats returns a snapshot of the cache's hit/miss/eviction counters.
+func (c *ARCCache[K, V]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.stats
+}
+
+// StartJanitor launches a single background goroutine that sweeps
+// expired entries out of the T1 and T2 sub-caches every sweepInterval.
+// It is a no-op if sweepInterval is not positive or a janitor is
+// already running. Call Close to stop it.
+func (c *ARCCache[K, V]) StartJanitor(sweepInterval time.Duration) {
+	if sweepInterval <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	if c.janitorStop != nil {
+		c.mu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	c.janitorStop = stop
+	c.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(sweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.sweepAll()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// sweepAll removes expired entries from the T1 and T2 sub-caches under
+// a single lock, matching the locking order Get and Peek already use
+// when delegating to them.
+func (c *ARCCache[K, V]) sweepAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.t1.sweep()
+	c.t2.sweep()
+}
+
+// Close stops the background janitor goroutine started by StartJanitor,
+// if any is running. It is safe to call even if StartJanitor never was.
+func (c *ARCCache[K, V]) Close() error {
+	c.mu.Lock()
+	stop := c.janitorStop
+	c.janitorStop = nil
+	c.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package cache
+
+import "time"
+
+// Item is a single cache entry.
+type Item[K comparable, V any] struct {
+	Key   K
+	Value V
+
+	// expiresAt is the time at which this entry becomes a miss for
+	// caches created with a TTL. The zero value means the entry never
+	// expires.
+	expiresAt time.Time
+}
+
+// expired reports whether the item has passed its expiration time.
+func (it *Item[K, V]) expired() bool {
+	return !it.expiresAt.IsZero() && time.Now().After(it.expiresAt)
+}
+
+// Cache is the common interface implemented by every eviction policy in
+// this package (LRU, TwoQueueCache, ARCCache, ...). It lets callers swap
+// the underlying policy without changing call sites.
+type Cache[K comparable, V any] interface {
+	// Get looks up a key's value from the cache, marking it as accessed.
+	Get(key K) (V, bool)
+
+	// Put adds a value to the cache, evicting an existing entry if
+	// necessary to make room.
+	Put(key K, value V)
+
+	// Peek returns a key's value without marking it as accessed.
+	Peek(key K) (V, bool)
+
+	// Remove removes the provided key, returning whether it was
+	// contained.
+	Remove(key K) bool
+
+	// Purge clears all cache entries.
+	Purge()
+
+	// Items returns a snapshot of all items in the cache.
+	Items() []*Item[K, V]
+
+	// Len returns the number of items in the cache.
+	Len() int
+}
+
+// Uint64Cache is the pre-generics instantiation of Cache, kept so existing
+// callers using uint64 keys and any values keep compiling unchanged.
+type Uint64Cache = Cache[uint64, any]
+
+var (
+	_ Uint64Cache = (*LRU[uint64, any])(nil)
+	_ Uint64Cache = (*TwoQueueCache[uint64, any])(nil)
+	_ Uint64Cache = (*ARCCache[uint64, any])(nil)
+	_ Uint64Cache = (*SieveCache[uint64, any])(nil)
+)
+
+// EvictReason explains why an entry left a cache, passed to an OnEvict
+// callback.
+type EvictReason int
+
+const (
+	// ReasonEvicted means the entry was evicted, either to make room or
+	// because its TTL passed, and no trace of it remains in the cache.
+	ReasonEvicted EvictReason = iota
+	// ReasonEvictedToGhost means the entry's value was evicted to make
+	// room, but a key-only "ghost" record survives (TwoQueueCache's
+	// evict queue, ARCCache's B1/B2) to inform future admission
+	// decisions.
+	ReasonEvictedToGhost
+	// ReasonReplaced means the entry's value was overwritten by a
+	// subsequent Put for the same key.
+	ReasonReplaced
+	// ReasonRemoved means the entry was removed by an explicit Remove
+	// call.
+	ReasonRemoved
+	// ReasonPurged means the entry was removed as part of a Purge call.
+	ReasonPurged
+)
+
+func (r EvictReason) String() string {
+	switch r {
+	case ReasonEvicted:
+		return "evicted"
+	case ReasonEvictedToGhost:
+		return "evicted-to-ghost"
+	case ReasonReplaced:
+		return "replaced"
+	case ReasonRemoved:
+		return "removed"
+	case ReasonPurged:
+		return "purged"
+	default:
+		return "unknown"
+	}
+}
+
+// Options holds the configurable knobs shared by this package's cache
+// constructors.
+type Options[K comparable, V any] struct {
+	// OnEvict, if set, is called whenever an entry leaves the cache,
+	// with the reason it left. It is called with the cache's internal
+	// lock held, so it must not call back into the same cache.
+	OnEvict func(key K, value V, reason EvictReason)
+}
+
+// Option configures a cache constructed by this package's New* functions.
+type Option[K comparable, V any] func(*Options[K, V])
+
+// WithOnEvict sets a callback invoked whenever an entry leaves the cache.
+func WithOnEvict[K comparable, V any](fn func(key K, value V, reason EvictReason)) Option[K, V] {
+	return func(o *Options[K, V]) { o.OnEvict = fn }
+}
+
+func buildOptions[K comparable, V any](opts []Option[K, V]) Options[K, V] {
+	var o Options[K, V]
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Stats holds point-in-time cache usage counters.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
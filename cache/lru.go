@@ -3,116 +3,407 @@ package cache
 import (
 	"container/list"
 	"errors"
+	"sync"
+	"time"
 )
 
-// LRU is 'Least-Recently-Used' cache.
-type LRU struct {
-	size      int
-	evictList *list.List
-	items     map[uint64]*list.Element
+// LRU is 'Least-Recently-Used' cache, generic over key type K and value
+// type V. It is safe for concurrent use by multiple goroutines.
+type LRU[K comparable, V any] struct {
+	mu sync.RWMutex
+
+	size       int
+	defaultTTL time.Duration
+	evictList  *list.List
+	items      map[K]*list.Element
+
+	onEvict func(key K, value V, reason EvictReason)
+	stats   Stats
+
+	// expiryHook, if set, is notified whenever this cache removes an
+	// entry for having passed its TTL, in addition to its own
+	// onEvict/stats. It lets a composite cache (TwoQueueCache, ARCCache)
+	// using this LRU as a sub-cache still observe and account for TTL
+	// expiry among its sub-caches' entries.
+	expiryHook func(key K, value V)
+
+	janitorStop chan struct{}
+}
+
+// Uint64LRU is the pre-generics instantiation of LRU, kept so existing
+// callers using uint64 keys and any values keep compiling unchanged.
+type Uint64LRU = LRU[uint64, any]
+
+// NewGenericLRU constructs an LRU generic over key type K and value type
+// V. Use NewLRU for the pre-generics uint64/any instantiation.
+func NewGenericLRU[K comparable, V any](size int, opts ...Option[K, V]) (*LRU[K, V], error) {
+	return newLRU[K, V](size, 0, opts)
+}
+
+// NewLRU constructs an LRU[uint64, any], matching the signature this
+// package exposed before the generics migration. Use NewGenericLRU for
+// other key/value types.
+func NewLRU(size int, opts ...Option[uint64, any]) (*Uint64LRU, error) {
+	return NewGenericLRU[uint64, any](size, opts...)
 }
 
-type Item struct {
-	Key   uint64
-	Value any
+// NewLRUWithTTL constructs an LRU whose entries expire defaultTTL after
+// being written, unless overridden per-entry via PutWithTTL. A defaultTTL
+// of zero disables expiration by default. Expired entries are removed
+// lazily on access; call StartJanitor to also sweep them in the
+// background.
+func NewLRUWithTTL[K comparable, V any](size int, defaultTTL time.Duration, opts ...Option[K, V]) (*LRU[K, V], error) {
+	return newLRU[K, V](size, defaultTTL, opts)
 }
 
-func NewLRU(size int) (*LRU, error) {
+func newLRU[K comparable, V any](size int, defaultTTL time.Duration, opts []Option[K, V]) (*LRU[K, V], error) {
 	if size <= 0 {
 		return nil, errors.New("must provide a positive size")
 	}
-	return &LRU{
-		size:      size,
-		evictList: list.New(),
-		items:     make(map[uint64]*list.Element),
+	o := buildOptions(opts)
+	return &LRU[K, V]{
+		size:       size,
+		defaultTTL: defaultTTL,
+		evictList:  list.New(),
+		items:      make(map[K]*list.Element),
+		onEvict:    o.OnEvict,
 	}, nil
 }
 
-func (c *LRU) Get(key uint64) (any, bool) {
+// NewUint64LRU is an explicit-name alias for NewLRU.
+func NewUint64LRU(size int, opts ...Option[uint64, any]) (*Uint64LRU, error) {
+	return NewLRU(size, opts...)
+}
+
+// onExpire registers fn to be called whenever this cache removes an
+// entry for having expired. It is unexported because it is only meant
+// for composite caches in this package to observe their sub-caches'
+// expiry, not for general use.
+func (c *LRU[K, V]) onExpire(fn func(key K, value V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expiryHook = fn
+}
+
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if ele, ok := c.items[key]; ok {
+		item := ele.Value.(*Item[K, V])
+		if item.expired() {
+			c.removeExpired(ele)
+			c.stats.Misses++
+			var zero V
+			return zero, false
+		}
 		c.evictList.MoveToFront(ele)
-		return ele.Value.(*Item).Value, true
+		c.stats.Hits++
+		return item.Value, true
 	}
-	return nil, false
+	c.stats.Misses++
+	var zero V
+	return zero, false
+}
+
+// Put adds a value to the cache using the cache's default TTL, if one was
+// configured via NewLRUWithTTL.
+func (c *LRU[K, V]) Put(key K, value V) {
+	c.PutWithTTL(key, value, c.defaultTTL)
 }
 
-func (c *LRU) Put(key uint64, value any) {
+// PutWithTTL adds a value to the cache that expires after ttl. A ttl of
+// zero or less means the entry never expires, regardless of the cache's
+// default TTL.
+func (c *LRU[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	c.putItem(key, value, expiresAt)
+}
+
+// putItem inserts or overwrites key with value, expiring at the given
+// absolute time (the zero value means it never expires). It exists
+// alongside PutWithTTL so composite caches (TwoQueueCache, ARCCache) can
+// move an entry between sub-caches while preserving its original
+// expiration, rather than re-deriving one from the destination's default
+// TTL.
+func (c *LRU[K, V]) putItem(key K, value V, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if ele, ok := c.items[key]; ok {
 		c.evictList.MoveToFront(ele)
-		ele.Value.(*Item).Value = value
+		item := ele.Value.(*Item[K, V])
+		old := item.Value
+		item.Value = value
+		item.expiresAt = expiresAt
+		c.notifyEvict(key, old, ReasonReplaced)
 		return
 	}
 
-	item := &Item{Key: key, Value: value}
+	item := &Item[K, V]{Key: key, Value: value, expiresAt: expiresAt}
 	ele := c.evictList.PushFront(item)
 	c.items[key] = ele
-	if c.Len() > c.size {
+	if c.evictList.Len() > c.size {
 		c.removeOldest()
 	}
 }
 
-func (c *LRU) Peek(key uint64) (any, bool) {
+// peekItem returns a copy of the live item stored for key, without
+// marking it as accessed. Unlike Peek, it also exposes the item's
+// expiration so callers can carry it over when moving the entry to
+// another LRU. Callers must not hold c.mu.
+func (c *LRU[K, V]) peekItem(key K) (*Item[K, V], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if ele, ok := c.items[key]; ok {
-		return ele.Value.(*Item).Value, ok
+		item := ele.Value.(*Item[K, V])
+		if item.expired() {
+			c.removeExpired(ele)
+			return nil, false
+		}
+		clone := *item
+		return &clone, true
 	}
 	return nil, false
 }
 
-func (c *LRU) Remove(key uint64) {
-	c.removeIfExist(key)
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ele, ok := c.items[key]; ok {
+		item := ele.Value.(*Item[K, V])
+		if item.expired() {
+			c.removeExpired(ele)
+			c.stats.Misses++
+			var zero V
+			return zero, false
+		}
+		c.stats.Hits++
+		return item.Value, true
+	}
+	c.stats.Misses++
+	var zero V
+	return zero, false
 }
 
-func (c *LRU) Purge() {
+func (c *LRU[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.removeIfExist(key)
+}
+
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.onEvict != nil {
+		for ele := c.evictList.Front(); ele != nil; ele = ele.Next() {
+			item := ele.Value.(*Item[K, V])
+			c.onEvict(item.Key, item.Value, ReasonPurged)
+		}
+	}
+
 	for k := range c.items {
 		delete(c.items, k)
 	}
 	c.evictList.Init()
 }
 
-func (c *LRU) Items() []*Item {
-	items := make([]*Item, 0, c.evictList.Len())
-	for ele := c.evictList.Front(); ele != nil; ele = ele.Next() {
-		clone := *ele.Value.(*Item)
+// Items returns a snapshot of all live (non-expired) items in the cache.
+func (c *LRU[K, V]) Items() []*Item[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items := make([]*Item[K, V], 0, c.evictList.Len())
+	for ele, next := c.evictList.Front(), (*list.Element)(nil); ele != nil; ele = next {
+		next = ele.Next()
+		item := ele.Value.(*Item[K, V])
+		if item.expired() {
+			c.removeExpired(ele)
+			continue
+		}
+		clone := *item
 		items = append(items, &clone)
 	}
 	return items
 }
 
-func (c *LRU) contains(key uint64) bool {
-	_, ok := c.items[key]
-	return ok
+// Contains reports whether key is present and unexpired in the cache,
+// without updating its recency.
+func (c *LRU[K, V]) Contains(key K) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ele, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	return !ele.Value.(*Item[K, V]).expired()
 }
 
-func (c *LRU) removeOldest() {
+// Stats returns a snapshot of the cache's hit/miss/eviction counters.
+func (c *LRU[K, V]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.stats
+}
+
+// removeOldest evicts the least-recently-used entry. Callers must hold c.mu.
+func (c *LRU[K, V]) removeOldest() {
 	ele := c.evictList.Back()
 	if ele != nil {
-		c.removeElement(ele)
+		c.removeElement(ele, ReasonEvicted)
 	}
 }
 
-func (c *LRU) getAndRemoveOldest() (uint64, any, bool) {
+// RemoveOldest evicts the least-recently-used entry.
+func (c *LRU[K, V]) RemoveOldest() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeOldest()
+}
+
+// GetAndRemoveOldest evicts and returns the least-recently-used entry.
+func (c *LRU[K, V]) GetAndRemoveOldest() (K, V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	ele := c.evictList.Back()
 	if ele != nil {
-		c.removeElement(ele)
-		return ele.Value.(*Item).Key, ele.Value.(*Item).Value, true
+		item := ele.Value.(*Item[K, V])
+		key, value := item.Key, item.Value
+		c.removeElement(ele, ReasonEvicted)
+		return key, value, true
 	}
-	return 0, nil, false
+	var zeroK K
+	var zeroV V
+	return zeroK, zeroV, false
 }
 
-func (c *LRU) removeElement(ele *list.Element) {
+// removeElement unlinks ele from the eviction list and the lookup map,
+// then reports reason to the cache's OnEvict callback, if any. Callers
+// must hold c.mu.
+func (c *LRU[K, V]) removeElement(ele *list.Element, reason EvictReason) {
 	c.evictList.Remove(ele)
-	item := ele.Value.(*Item)
+	item := ele.Value.(*Item[K, V])
 	delete(c.items, item.Key)
+	c.notifyEvict(item.Key, item.Value, reason)
 }
 
-func (c *LRU) removeIfExist(key uint64) bool {
+// removeExpired unlinks an expired entry, reporting it through the
+// cache's own notifyEvict as well as through expiryHook, if set. Callers
+// must hold c.mu.
+func (c *LRU[K, V]) removeExpired(ele *list.Element) {
+	item := ele.Value.(*Item[K, V])
+	key, value := item.Key, item.Value
+	c.removeElement(ele, ReasonEvicted)
+	if c.expiryHook != nil {
+		c.expiryHook(key, value)
+	}
+}
+
+// notifyEvict updates eviction stats and invokes the OnEvict callback, if
+// any. Callers must hold c.mu.
+func (c *LRU[K, V]) notifyEvict(key K, value V, reason EvictReason) {
+	if reason == ReasonEvicted || reason == ReasonEvictedToGhost {
+		c.stats.Evictions++
+	}
+	if c.onEvict != nil {
+		c.onEvict(key, value, reason)
+	}
+}
+
+// removeIfExist removes key if present, reporting whether it was removed.
+// Callers must hold c.mu.
+func (c *LRU[K, V]) removeIfExist(key K) bool {
 	if ele, ok := c.items[key]; ok {
-		c.removeElement(ele)
+		c.removeElement(ele, ReasonRemoved)
 		return ok
 	}
 	return false
 }
 
-func (c *LRU) Len() int {
-	return c.evictList.Len()
+// Len returns the number of live (non-expired) items in the cache.
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for ele, next := c.evictList.Front(), (*list.Element)(nil); ele != nil; ele = next {
+		next = ele.Next()
+		if ele.Value.(*Item[K, V]).expired() {
+			c.removeExpired(ele)
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// StartJanitor launches a background goroutine that sweeps expired
+// entries out of the cache every sweepInterval. It is a no-op if
+// sweepInterval is not positive or a janitor is already running. Call
+// Close to stop it.
+func (c *LRU[K, V]) StartJanitor(sweepInterval time.Duration) {
+	if sweepInterval <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	if c.janitorStop != nil {
+		c.mu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	c.janitorStop = stop
+	c.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(sweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.sweep()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// sweep removes every expired entry from the cache.
+func (c *LRU[K, V]) sweep() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for ele, prev := c.evictList.Back(), (*list.Element)(nil); ele != nil; ele = prev {
+		prev = ele.Prev()
+		if ele.Value.(*Item[K, V]).expired() {
+			c.removeExpired(ele)
+		}
+	}
+}
+
+// Close stops the background janitor goroutine started by StartJanitor,
+// if any is running. It is safe to call even if StartJanitor never was.
+func (c *LRU[K, V]) Close() error {
+	c.mu.Lock()
+	stop := c.janitorStop
+	c.janitorStop = nil
+	c.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+	}
+	return nil
 }
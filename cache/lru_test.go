@@ -0,0 +1,184 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRU_PutWithTTLExpires(t *testing.T) {
+	c, err := NewGenericLRU[string, string](10)
+	if err != nil {
+		t.Fatalf("NewGenericLRU: %v", err)
+	}
+	c.PutWithTTL("a", "v1", 10*time.Millisecond)
+
+	if v, ok := c.Get("a"); !ok || v != "v1" {
+		t.Fatalf("Get before expiry = (%q, %v); want (v1, true)", v, ok)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("Get after ttl expired = ok; want miss")
+	}
+}
+
+func TestLRU_OnEvictFiresOnExpiry(t *testing.T) {
+	var reasons []EvictReason
+	c, err := NewGenericLRU[string, string](10, WithOnEvict(func(key, value string, reason EvictReason) {
+		reasons = append(reasons, reason)
+	}))
+	if err != nil {
+		t.Fatalf("NewGenericLRU: %v", err)
+	}
+	c.PutWithTTL("a", "v1", 10*time.Millisecond)
+
+	time.Sleep(30 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("Get after ttl expired = ok; want miss")
+	}
+	if len(reasons) != 1 || reasons[0] != ReasonEvicted {
+		t.Fatalf("OnEvict reasons = %v; want [%v]", reasons, ReasonEvicted)
+	}
+	if stats := c.Stats(); stats.Evictions != 1 {
+		t.Fatalf("Stats().Evictions = %d; want 1", stats.Evictions)
+	}
+}
+
+func TestLRU_OnEvictFiresOnCapacityEviction(t *testing.T) {
+	var reasons []EvictReason
+	c, err := NewGenericLRU[string, string](2, WithOnEvict(func(key, value string, reason EvictReason) {
+		reasons = append(reasons, reason)
+	}))
+	if err != nil {
+		t.Fatalf("NewGenericLRU: %v", err)
+	}
+	c.Put("a", "v1")
+	c.Put("b", "v2")
+	c.Put("c", "v3")
+
+	if len(reasons) != 1 || reasons[0] != ReasonEvicted {
+		t.Fatalf("OnEvict reasons = %v; want [%v]", reasons, ReasonEvicted)
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("Get(a) after capacity eviction = ok; want evicted")
+	}
+	if stats := c.Stats(); stats.Evictions != 1 {
+		t.Fatalf("Stats().Evictions = %d; want 1", stats.Evictions)
+	}
+}
+
+func TestLRU_OnEvictFiresOnReplace(t *testing.T) {
+	var reasons []EvictReason
+	c, err := NewGenericLRU[string, string](10, WithOnEvict(func(key, value string, reason EvictReason) {
+		reasons = append(reasons, reason)
+	}))
+	if err != nil {
+		t.Fatalf("NewGenericLRU: %v", err)
+	}
+	c.Put("a", "v1")
+	c.Put("a", "v2")
+
+	if len(reasons) != 1 || reasons[0] != ReasonReplaced {
+		t.Fatalf("OnEvict reasons = %v; want [%v]", reasons, ReasonReplaced)
+	}
+}
+
+func TestLRU_OnEvictFiresOnRemove(t *testing.T) {
+	var reasons []EvictReason
+	c, err := NewGenericLRU[string, string](10, WithOnEvict(func(key, value string, reason EvictReason) {
+		reasons = append(reasons, reason)
+	}))
+	if err != nil {
+		t.Fatalf("NewGenericLRU: %v", err)
+	}
+	c.Put("a", "v1")
+	if !c.Remove("a") {
+		t.Fatalf("Remove(a) = false; want true")
+	}
+
+	if len(reasons) != 1 || reasons[0] != ReasonRemoved {
+		t.Fatalf("OnEvict reasons = %v; want [%v]", reasons, ReasonRemoved)
+	}
+}
+
+func TestLRU_OnEvictFiresOnPurge(t *testing.T) {
+	var reasons []EvictReason
+	c, err := NewGenericLRU[string, string](10, WithOnEvict(func(key, value string, reason EvictReason) {
+		reasons = append(reasons, reason)
+	}))
+	if err != nil {
+		t.Fatalf("NewGenericLRU: %v", err)
+	}
+	c.Put("a", "v1")
+	c.Put("b", "v2")
+	c.Purge()
+
+	if len(reasons) != 2 || reasons[0] != ReasonPurged || reasons[1] != ReasonPurged {
+		t.Fatalf("OnEvict reasons = %v; want two %v", reasons, ReasonPurged)
+	}
+	if n := c.Len(); n != 0 {
+		t.Fatalf("Len() after Purge = %d; want 0", n)
+	}
+}
+
+func TestLRU_ItemsSkipsExpired(t *testing.T) {
+	c, err := NewGenericLRU[string, string](10)
+	if err != nil {
+		t.Fatalf("NewGenericLRU: %v", err)
+	}
+	c.PutWithTTL("a", "v1", 10*time.Millisecond)
+	c.Put("b", "v2")
+
+	time.Sleep(30 * time.Millisecond)
+
+	items := c.Items()
+	if len(items) != 1 || items[0].Key != "b" {
+		t.Fatalf("Items() = %v; want only [b]", items)
+	}
+}
+
+func TestLRU_LenExcludesExpired(t *testing.T) {
+	c, err := NewGenericLRU[string, string](10)
+	if err != nil {
+		t.Fatalf("NewGenericLRU: %v", err)
+	}
+	c.PutWithTTL("a", "v1", 10*time.Millisecond)
+	c.Put("b", "v2")
+
+	if n := c.Len(); n != 2 {
+		t.Fatalf("Len() before expiry = %d; want 2", n)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if n := c.Len(); n != 1 {
+		t.Fatalf("Len() after expiry = %d; want 1", n)
+	}
+}
+
+func TestLRU_JanitorSweepsAndStopsOnClose(t *testing.T) {
+	c, err := NewGenericLRU[string, string](10)
+	if err != nil {
+		t.Fatalf("NewGenericLRU: %v", err)
+	}
+	c.PutWithTTL("a", "v1", 10*time.Millisecond)
+	c.StartJanitor(15 * time.Millisecond)
+	defer c.Close()
+
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		if len(c.Items()) == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if items := c.Items(); len(items) != 0 {
+		t.Fatalf("Items() after janitor sweep = %v; want empty", items)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package cache
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+)
+
+// SieveCache implements the SIEVE eviction policy: a scan-resistant cache
+// with LRU-like O(1) insert cost but no per-hit list mutation. Each entry
+// carries a "visited" bit that Get sets without moving the entry; Put
+// inserts new entries at the head of the list. Eviction walks a "hand"
+// pointer backward from where the previous eviction left it (the tail,
+// the first time), clearing the visited bit of anything it passes, and
+// evicts the first entry it finds with the bit already clear. It is safe
+// for concurrent use by multiple goroutines.
+type SieveCache[K comparable, V any] struct {
+	mu sync.RWMutex
+
+	size  int
+	ll    *list.List
+	items map[K]*list.Element
+	hand  *list.Element
+}
+
+// Uint64SieveCache is the pre-generics-style instantiation of SieveCache,
+// matching the uint64/any convention the rest of this package uses for
+// backward compatibility.
+type Uint64SieveCache = SieveCache[uint64, any]
+
+type sieveEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	visited bool
+}
+
+func NewSieveCache[K comparable, V any](size int) (*SieveCache[K, V], error) {
+	if size <= 0 {
+		return nil, errors.New("must provide a positive size")
+	}
+	return &SieveCache[K, V]{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[K]*list.Element),
+	}, nil
+}
+
+// NewUint64SieveCache constructs a SieveCache[uint64, any].
+func NewUint64SieveCache(size int) (*Uint64SieveCache, error) {
+	return NewSieveCache[uint64, any](size)
+}
+
+func (c *SieveCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ele, ok := c.items[key]; ok {
+		ele.Value.(*sieveEntry[K, V]).visited = true
+		return ele.Value.(*sieveEntry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *SieveCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ele, ok := c.items[key]; ok {
+		ele.Value.(*sieveEntry[K, V]).value = value
+		return
+	}
+
+	if c.ll.Len() >= c.size {
+		c.evict()
+	}
+
+	ele := c.ll.PushFront(&sieveEntry[K, V]{key: key, value: value})
+	c.items[key] = ele
+}
+
+// evict runs the hand pointer backward from its current position
+// (starting at the tail the first time it's called), clearing the
+// visited bit of every node it passes over, and removes the first node
+// whose bit is already clear. Callers must hold c.mu.
+func (c *SieveCache[K, V]) evict() {
+	hand := c.hand
+	if hand == nil {
+		hand = c.ll.Back()
+	}
+
+	for hand != nil && hand.Value.(*sieveEntry[K, V]).visited {
+		hand.Value.(*sieveEntry[K, V]).visited = false
+		hand = hand.Prev()
+		if hand == nil {
+			hand = c.ll.Back()
+		}
+	}
+	if hand == nil {
+		return
+	}
+
+	next := hand.Prev()
+	delete(c.items, hand.Value.(*sieveEntry[K, V]).key)
+	c.ll.Remove(hand)
+
+	if next == nil {
+		next = c.ll.Back()
+	}
+	c.hand = next
+}
+
+func (c *SieveCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if ele, ok := c.items[key]; ok {
+		return ele.Value.(*sieveEntry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *SieveCache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ele, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	if c.hand == ele {
+		c.hand = ele.Prev()
+	}
+	delete(c.items, key)
+	c.ll.Remove(ele)
+	return true
+}
+
+func (c *SieveCache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k := range c.items {
+		delete(c.items, k)
+	}
+	c.ll.Init()
+	c.hand = nil
+}
+
+func (c *SieveCache[K, V]) Items() []*Item[K, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	items := make([]*Item[K, V], 0, c.ll.Len())
+	for ele := c.ll.Front(); ele != nil; ele = ele.Next() {
+		ent := ele.Value.(*sieveEntry[K, V])
+		items = append(items, &Item[K, V]{Key: ent.key, Value: ent.value})
+	}
+	return items
+}
+
+func (c *SieveCache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.ll.Len()
+}
@@ -0,0 +1,63 @@
+package cache
+
+import "testing"
+
+func TestSieveCache_VisitedSurvivesEviction(t *testing.T) {
+	c, err := NewSieveCache[string, string](2)
+	if err != nil {
+		t.Fatalf("NewSieveCache: %v", err)
+	}
+	c.Put("a", "v1")
+	c.Put("b", "v2")
+
+	// Mark "a" visited; "b" stays unvisited.
+	if v, ok := c.Get("a"); !ok || v != "v1" {
+		t.Fatalf("Get(a) = (%q, %v); want (v1, true)", v, ok)
+	}
+
+	c.Put("c", "v3")
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("Get(b) = ok; want evicted")
+	}
+	if v, ok := c.Get("a"); !ok || v != "v1" {
+		t.Fatalf("Get(a) after eviction = (%q, %v); want (v1, true)", v, ok)
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatalf("Get(c) = miss; want present")
+	}
+}
+
+func TestSieveCache_FullWrapClearsAllVisitedBits(t *testing.T) {
+	c, err := NewSieveCache[string, string](3)
+	if err != nil {
+		t.Fatalf("NewSieveCache: %v", err)
+	}
+	c.Put("a", "v1")
+	c.Put("b", "v2")
+	c.Put("c", "v3")
+
+	// Visit every entry, so the hand must wrap all the way back to the
+	// tail, clearing every bit, before it finds one to evict.
+	c.Get("a")
+	c.Get("b")
+	c.Get("c")
+
+	c.Put("d", "v4")
+
+	if c.Len() != 3 {
+		t.Fatalf("Len() = %d; want 3", c.Len())
+	}
+	// With every entry visited, the hand clears every bit on its way
+	// back to the tail and evicts the tail ("a", the oldest entry) once
+	// it wraps back around to it with a now-clear bit.
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("Get(a) = ok; want evicted after full wrap")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Fatalf("Get(b) = miss; want present")
+	}
+	if _, ok := c.Get("d"); !ok {
+		t.Fatalf("Get(d) = miss; want present")
+	}
+}
@@ -1,6 +1,10 @@
 package cache
 
-import "errors"
+import (
+	"errors"
+	"sync"
+	"time"
+)
 
 const (
 	// defaultRecentRatio is the ratio of the 2Q cache dedicated
@@ -12,20 +16,57 @@ const (
 	defaultGhostRatio = 0.50
 )
 
-type TwoQueueCache struct {
+// TwoQueueCache is generic over key type K and value type V. It is safe
+// for concurrent use by multiple goroutines.
+type TwoQueueCache[K comparable, V any] struct {
+	mu sync.RWMutex
+
 	size       int
 	recentSize int
+	defaultTTL time.Duration
+
+	recent   *LRU[K, V]
+	frequent *LRU[K, V]
+	evict    *LRU[K, any]
+
+	onEvict func(key K, value V, reason EvictReason)
+	stats   Stats
 
-	recent   *LRU
-	frequent *LRU
-	evict    *LRU
+	janitorStop chan struct{}
 }
 
-func NewTwoQueueCache(size int) (*TwoQueueCache, error) {
-	return newTowQueueParams(size, defaultRecentRatio, defaultGhostRatio)
+// Uint64TwoQueueCache is the pre-generics instantiation of TwoQueueCache,
+// kept so existing callers using uint64 keys and any values keep
+// compiling unchanged.
+type Uint64TwoQueueCache = TwoQueueCache[uint64, any]
+
+// NewGenericTwoQueueCache constructs a TwoQueueCache generic over key
+// type K and value type V. Use NewTwoQueueCache for the pre-generics
+// uint64/any instantiation.
+func NewGenericTwoQueueCache[K comparable, V any](size int, opts ...Option[K, V]) (*TwoQueueCache[K, V], error) {
+	return newTowQueueParams[K, V](size, defaultRecentRatio, defaultGhostRatio, 0, opts)
+}
+
+// NewTwoQueueCache constructs a TwoQueueCache[uint64, any], matching the
+// signature this package exposed before the generics migration. Use
+// NewGenericTwoQueueCache for other key/value types.
+func NewTwoQueueCache(size int, opts ...Option[uint64, any]) (*Uint64TwoQueueCache, error) {
+	return NewGenericTwoQueueCache[uint64, any](size, opts...)
 }
 
-func newTowQueueParams(size int, recentRatio, ghostRatio float64) (*TwoQueueCache, error) {
+// NewTwoQueueCacheWithTTL constructs a TwoQueueCache whose entries expire
+// defaultTTL after being written, unless overridden per-entry via
+// PutWithTTL. See NewLRUWithTTL for expiration semantics.
+func NewTwoQueueCacheWithTTL[K comparable, V any](size int, defaultTTL time.Duration, opts ...Option[K, V]) (*TwoQueueCache[K, V], error) {
+	return newTowQueueParams[K, V](size, defaultRecentRatio, defaultGhostRatio, defaultTTL, opts)
+}
+
+// NewUint64TwoQueueCache is an explicit-name alias for NewTwoQueueCache.
+func NewUint64TwoQueueCache(size int, opts ...Option[uint64, any]) (*Uint64TwoQueueCache, error) {
+	return NewTwoQueueCache(size, opts...)
+}
+
+func newTowQueueParams[K comparable, V any](size int, recentRatio, ghostRatio float64, defaultTTL time.Duration, opts []Option[K, V]) (*TwoQueueCache[K, V], error) {
 	if size <= 0 {
 		return nil, errors.New("must provide a positive size")
 	}
@@ -39,65 +80,97 @@ func newTowQueueParams(size int, recentRatio, ghostRatio float64) (*TwoQueueCach
 	recentSize := int(float64(size) * recentRatio)
 	evictSize := int(float64(size) * ghostRatio)
 
-	recent, err := NewLRU(size)
+	recent, err := NewLRUWithTTL[K, V](size, defaultTTL)
 	if err != nil {
 		return nil, err
 	}
-	frequent, err := NewLRU(size)
+	frequent, err := NewLRUWithTTL[K, V](size, defaultTTL)
 	if err != nil {
 		return nil, err
 	}
-	evict, err := NewLRU(evictSize)
+	evict, err := NewGenericLRU[K, any](evictSize)
 	if err != nil {
 		return nil, err
 	}
-	return &TwoQueueCache{
+	o := buildOptions(opts)
+	c := &TwoQueueCache[K, V]{
 		size:       size,
 		recentSize: recentSize,
+		defaultTTL: defaultTTL,
 		recent:     recent,
 		frequent:   frequent,
 		evict:      evict,
-	}, nil
+		onEvict:    o.OnEvict,
+	}
+
+	// Entries that expire inside a sub-cache (lazily on access, or via
+	// sweepAll) still leave the cache for good; report them the same
+	// way as any other eviction.
+	recent.onExpire(func(key K, value V) { c.notifyEvict(key, value, ReasonEvicted) })
+	frequent.onExpire(func(key K, value V) { c.notifyEvict(key, value, ReasonEvicted) })
+
+	return c, nil
 }
 
-func (c *TwoQueueCache) Get(key uint64) (any, bool) {
+func (c *TwoQueueCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if val, ok := c.frequent.Get(key); ok {
+		c.stats.Hits++
 		return val, ok
 	}
 
-	if val, ok := c.recent.Peek(key); ok {
+	if item, ok := c.recent.peekItem(key); ok {
 		c.recent.Remove(key)
-		c.frequent.Put(key, val)
-		return val, ok
+		c.frequent.putItem(key, item.Value, item.expiresAt)
+		c.stats.Hits++
+		return item.Value, true
 	}
 
-	return nil, false
+	c.stats.Misses++
+	var zero V
+	return zero, false
+}
+
+// Put adds a value to the cache using the cache's default TTL, if one was
+// configured via NewTwoQueueCacheWithTTL.
+func (c *TwoQueueCache[K, V]) Put(key K, value V) {
+	c.PutWithTTL(key, value, c.defaultTTL)
 }
 
-func (c *TwoQueueCache) Put(key uint64, value any) {
-	if c.frequent.contains(key) {
-		c.frequent.Put(key, value)
+// PutWithTTL adds a value to the cache that expires after ttl. A ttl of
+// zero or less means the entry never expires.
+func (c *TwoQueueCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if old, ok := c.frequent.Peek(key); ok {
+		c.frequent.PutWithTTL(key, value, ttl)
+		c.notifyEvict(key, old, ReasonReplaced)
 		return
 	}
 
-	if c.recent.contains(key) {
+	if old, ok := c.recent.Peek(key); ok {
 		c.recent.Remove(key)
-		c.frequent.Put(key, value)
+		c.frequent.PutWithTTL(key, value, ttl)
+		c.notifyEvict(key, old, ReasonReplaced)
 		return
 	}
 
-	if c.evict.contains(key) {
+	if c.evict.Contains(key) {
 		c.ensureSpace(true)
 		c.evict.Remove(key)
-		c.frequent.Put(key, value)
+		c.frequent.PutWithTTL(key, value, ttl)
 		return
 	}
 
 	c.ensureSpace(false)
-	c.recent.Put(key, value)
+	c.recent.PutWithTTL(key, value, ttl)
 }
 
-func (c *TwoQueueCache) ensureSpace(recentEvict bool) {
+// ensureSpace makes room in the cache if needed. Callers must hold c.mu.
+func (c *TwoQueueCache[K, V]) ensureSpace(recentEvict bool) {
 	// If we have space, nothing to do
 	if c.recent.Len()+c.frequent.Len() < c.size {
 		return
@@ -105,47 +178,171 @@ func (c *TwoQueueCache) ensureSpace(recentEvict bool) {
 
 	// If the recent buffer is larger than the target, evict from there
 	if c.recent.Len() > 0 && (c.recent.Len() > c.recentSize || (c.recent.Len() == c.recentSize && !recentEvict)) {
-		k, _, _ := c.recent.getAndRemoveOldest()
-		c.evict.Put(k, nil)
+		if k, v, ok := c.recent.GetAndRemoveOldest(); ok {
+			c.evict.Put(k, nil)
+			c.notifyEvict(k, v, ReasonEvictedToGhost)
+		}
 		return
 	}
 
-	// Remove from the frequent list otherwise
-	c.frequent.removeOldest()
+	// Remove from the frequent list otherwise; it has no ghost queue, so
+	// this entry is evicted for good.
+	if k, v, ok := c.frequent.GetAndRemoveOldest(); ok {
+		c.notifyEvict(k, v, ReasonEvicted)
+	}
 }
 
-func (c *TwoQueueCache) Peek(key uint64) (any, bool) {
+// notifyEvict updates eviction stats and invokes the OnEvict callback, if
+// any. Callers must hold c.mu.
+func (c *TwoQueueCache[K, V]) notifyEvict(key K, value V, reason EvictReason) {
+	if reason == ReasonEvicted || reason == ReasonEvictedToGhost {
+		c.stats.Evictions++
+	}
+	if c.onEvict != nil {
+		c.onEvict(key, value, reason)
+	}
+}
+
+// Peek takes the full lock, not RLock, because a peeked entry found
+// expired in recent/frequent is removed on the spot and reported
+// through the expiry hook, which mutates c.stats and may invoke
+// c.onEvict.
+func (c *TwoQueueCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if val, ok := c.frequent.Peek(key); ok {
+		c.stats.Hits++
 		return val, ok
 	}
-	return c.recent.Peek(key)
+	if val, ok := c.recent.Peek(key); ok {
+		c.stats.Hits++
+		return val, ok
+	}
+	c.stats.Misses++
+	var zero V
+	return zero, false
 }
 
-func (c *TwoQueueCache) Remove(key uint64) {
-	if c.frequent.removeIfExist(key) {
-		return
-	}
-	if c.recent.removeIfExist(key) {
-		return
+func (c *TwoQueueCache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if val, ok := c.frequent.Peek(key); ok {
+		c.frequent.Remove(key)
+		c.notifyEvict(key, val, ReasonRemoved)
+		return true
 	}
-	if c.evict.removeIfExist(key) {
-		return
+	if val, ok := c.recent.Peek(key); ok {
+		c.recent.Remove(key)
+		c.notifyEvict(key, val, ReasonRemoved)
+		return true
 	}
+	return c.evict.Remove(key)
 }
 
-func (c *TwoQueueCache) Purge() {
+func (c *TwoQueueCache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.onEvict != nil {
+		for _, it := range c.recent.Items() {
+			c.onEvict(it.Key, it.Value, ReasonPurged)
+		}
+		for _, it := range c.frequent.Items() {
+			c.onEvict(it.Key, it.Value, ReasonPurged)
+		}
+	}
+
 	c.recent.Purge()
 	c.frequent.Purge()
 	c.evict.Purge()
 }
 
-func (c *TwoQueueCache) Items() []*Item {
-	elems := make([]*Item, 0, c.Len())
+// Items takes the full lock, not RLock, for the same reason as Peek:
+// pruning expired entries out of recent/frequent mutates c.stats via
+// the expiry hook.
+func (c *TwoQueueCache[K, V]) Items() []*Item[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elems := make([]*Item[K, V], 0, c.recent.Len()+c.frequent.Len())
 	elems = append(elems, c.recent.Items()...)
 	elems = append(elems, c.frequent.Items()...)
 	return elems
 }
 
-func (c *TwoQueueCache) Len() int {
+// Len takes the full lock, not RLock, for the same reason as Peek:
+// pruning expired entries out of recent/frequent mutates c.stats via
+// the expiry hook.
+func (c *TwoQueueCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	return c.recent.Len() + c.frequent.Len()
 }
+
+// Stats returns a snapshot of the cache's hit/miss/eviction counters.
+func (c *TwoQueueCache[K, V]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.stats
+}
+
+// StartJanitor launches a single background goroutine that sweeps
+// expired entries out of the recent and frequent sub-caches every
+// sweepInterval. It is a no-op if sweepInterval is not positive or a
+// janitor is already running. Call Close to stop it.
+func (c *TwoQueueCache[K, V]) StartJanitor(sweepInterval time.Duration) {
+	if sweepInterval <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	if c.janitorStop != nil {
+		c.mu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	c.janitorStop = stop
+	c.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(sweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.sweepAll()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// sweepAll removes expired entries from the recent and frequent
+// sub-caches under a single lock, matching the locking order Get and
+// Peek already use when delegating to them.
+func (c *TwoQueueCache[K, V]) sweepAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.recent.sweep()
+	c.frequent.sweep()
+}
+
+// Close stops the background janitor goroutine started by StartJanitor,
+// if any is running. It is safe to call even if StartJanitor never was.
+func (c *TwoQueueCache[K, V]) Close() error {
+	c.mu.Lock()
+	stop := c.janitorStop
+	c.janitorStop = nil
+	c.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTwoQueueCache_PromotionPreservesTTL(t *testing.T) {
+	c, err := NewGenericTwoQueueCache[string, string](10)
+	if err != nil {
+		t.Fatalf("NewGenericTwoQueueCache: %v", err)
+	}
+	c.PutWithTTL("a", "v1", 10*time.Millisecond)
+
+	// Get promotes "a" from recent to frequent; it must not reset the TTL.
+	if v, ok := c.Get("a"); !ok || v != "v1" {
+		t.Fatalf("Get before expiry = (%q, %v); want (v1, true)", v, ok)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("Get after ttl expired = ok; want miss")
+	}
+}
+
+func TestTwoQueueCache_OnEvictFiresOnSubCacheExpiry(t *testing.T) {
+	var evictions int
+	c, err := NewTwoQueueCacheWithTTL[string, string](10, 10*time.Millisecond, WithOnEvict(func(key, value string, reason EvictReason) {
+		if reason == ReasonEvicted {
+			evictions++
+		}
+	}))
+	if err != nil {
+		t.Fatalf("NewTwoQueueCacheWithTTL: %v", err)
+	}
+	c.Put("a", "v1")
+
+	time.Sleep(30 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("Get after ttl expired = ok; want miss")
+	}
+	if evictions != 1 {
+		t.Fatalf("OnEvict fired %d times for expiry; want 1", evictions)
+	}
+	if stats := c.Stats(); stats.Evictions != 1 {
+		t.Fatalf("Stats().Evictions = %d; want 1", stats.Evictions)
+	}
+}
+
+func TestTwoQueueCache_PeekUpdatesStats(t *testing.T) {
+	c, err := NewGenericTwoQueueCache[string, string](10)
+	if err != nil {
+		t.Fatalf("NewGenericTwoQueueCache: %v", err)
+	}
+	c.Put("a", "v1")
+
+	if v, ok := c.Peek("a"); !ok || v != "v1" {
+		t.Fatalf("Peek(a) = (%q, %v); want (v1, true)", v, ok)
+	}
+	if _, ok := c.Peek("missing"); ok {
+		t.Fatalf("Peek(missing) = ok; want miss")
+	}
+
+	if stats := c.Stats(); stats.Hits != 1 || stats.Misses != 1 {
+		t.Fatalf("Stats() = %+v; want 1 hit, 1 miss", stats)
+	}
+}
+
+func TestTwoQueueCache_OnEvictFiresOnGhostEviction(t *testing.T) {
+	var reasons []EvictReason
+	c, err := NewGenericTwoQueueCache[string, string](4, WithOnEvict(func(key, value string, reason EvictReason) {
+		reasons = append(reasons, reason)
+	}))
+	if err != nil {
+		t.Fatalf("NewGenericTwoQueueCache: %v", err)
+	}
+	c.Put("a", "v1")
+	c.Put("b", "v2")
+	c.Put("c", "v3")
+	c.Put("d", "v4")
+	c.Put("e", "v5")
+
+	if len(reasons) != 1 || reasons[0] != ReasonEvictedToGhost {
+		t.Fatalf("OnEvict reasons = %v; want [%v]", reasons, ReasonEvictedToGhost)
+	}
+	if stats := c.Stats(); stats.Evictions != 1 {
+		t.Fatalf("Stats().Evictions = %d; want 1", stats.Evictions)
+	}
+}
+
+func TestTwoQueueCache_OnEvictFiresOnCapacityEviction(t *testing.T) {
+	var reasons []EvictReason
+	c, err := NewGenericTwoQueueCache[string, string](4, WithOnEvict(func(key, value string, reason EvictReason) {
+		reasons = append(reasons, reason)
+	}))
+	if err != nil {
+		t.Fatalf("NewGenericTwoQueueCache: %v", err)
+	}
+	// Promote every entry into the frequent queue, which has no ghost
+	// list, so once it alone fills the cache the next Put must evict
+	// straight out of it for good.
+	for _, k := range []string{"a", "b", "c", "d"} {
+		c.Put(k, "v")
+		if _, ok := c.Get(k); !ok {
+			t.Fatalf("Get(%s) = miss; want hit", k)
+		}
+	}
+	c.Put("e", "v5")
+
+	if len(reasons) != 1 || reasons[0] != ReasonEvicted {
+		t.Fatalf("OnEvict reasons = %v; want [%v]", reasons, ReasonEvicted)
+	}
+}
+
+func TestTwoQueueCache_OnEvictFiresOnReplace(t *testing.T) {
+	var reasons []EvictReason
+	c, err := NewGenericTwoQueueCache[string, string](10, WithOnEvict(func(key, value string, reason EvictReason) {
+		reasons = append(reasons, reason)
+	}))
+	if err != nil {
+		t.Fatalf("NewGenericTwoQueueCache: %v", err)
+	}
+	c.Put("a", "v1")
+	c.Put("a", "v2")
+
+	if len(reasons) != 1 || reasons[0] != ReasonReplaced {
+		t.Fatalf("OnEvict reasons = %v; want [%v]", reasons, ReasonReplaced)
+	}
+}
+
+func TestTwoQueueCache_OnEvictFiresOnRemove(t *testing.T) {
+	var reasons []EvictReason
+	c, err := NewGenericTwoQueueCache[string, string](10, WithOnEvict(func(key, value string, reason EvictReason) {
+		reasons = append(reasons, reason)
+	}))
+	if err != nil {
+		t.Fatalf("NewGenericTwoQueueCache: %v", err)
+	}
+	c.Put("a", "v1")
+	if !c.Remove("a") {
+		t.Fatalf("Remove(a) = false; want true")
+	}
+
+	if len(reasons) != 1 || reasons[0] != ReasonRemoved {
+		t.Fatalf("OnEvict reasons = %v; want [%v]", reasons, ReasonRemoved)
+	}
+}
+
+func TestTwoQueueCache_OnEvictFiresOnPurge(t *testing.T) {
+	var reasons []EvictReason
+	c, err := NewGenericTwoQueueCache[string, string](10, WithOnEvict(func(key, value string, reason EvictReason) {
+		reasons = append(reasons, reason)
+	}))
+	if err != nil {
+		t.Fatalf("NewGenericTwoQueueCache: %v", err)
+	}
+	c.Put("a", "v1")
+	c.Put("b", "v2")
+	c.Purge()
+
+	if len(reasons) != 2 || reasons[0] != ReasonPurged || reasons[1] != ReasonPurged {
+		t.Fatalf("OnEvict reasons = %v; want two %v", reasons, ReasonPurged)
+	}
+	if n := c.Len(); n != 0 {
+		t.Fatalf("Len() after Purge = %d; want 0", n)
+	}
+}
+
+func TestTwoQueueCache_ItemsAndLenExcludeExpired(t *testing.T) {
+	c, err := NewGenericTwoQueueCache[string, string](10)
+	if err != nil {
+		t.Fatalf("NewGenericTwoQueueCache: %v", err)
+	}
+	c.PutWithTTL("a", "v1", 10*time.Millisecond)
+	c.Put("b", "v2")
+
+	if n := c.Len(); n != 2 {
+		t.Fatalf("Len() before expiry = %d; want 2", n)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	items := c.Items()
+	if len(items) != 1 || items[0].Key != "b" {
+		t.Fatalf("Items() = %v; want only [b]", items)
+	}
+	if n := c.Len(); n != 1 {
+		t.Fatalf("Len() after expiry = %d; want 1", n)
+	}
+}
+
+func TestTwoQueueCache_JanitorSweepsAndStopsOnClose(t *testing.T) {
+	c, err := NewGenericTwoQueueCache[string, string](10)
+	if err != nil {
+		t.Fatalf("NewGenericTwoQueueCache: %v", err)
+	}
+	c.PutWithTTL("a", "v1", 10*time.Millisecond)
+	c.StartJanitor(15 * time.Millisecond)
+	defer c.Close()
+
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		if len(c.Items()) == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if items := c.Items(); len(items) != 0 {
+		t.Fatalf("Items() after janitor sweep = %v; want empty", items)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}